@@ -0,0 +1,102 @@
+package sseparser
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DispatchedEvent is the result of applying the WHATWG SSE dispatch
+// algorithm (https://html.spec.whatwg.org/#dispatchMessage) to one or
+// more field-events parsed by TryParse.
+type DispatchedEvent struct {
+	// ID is the stream's last-event-id at the time of dispatch.
+	ID string
+	// Type is the event's type, defaulting to "message".
+	Type string
+	// Data is every "data:" line's value joined with "\n".
+	Data string
+	// Retry is the server-suggested reconnection time, or zero if none has
+	// been set on this stream yet.
+	Retry time.Duration
+}
+
+// NextDispatched behaves like TryParse but applies the SSE dispatch
+// algorithm on top of it: "data:" lines are concatenated with "\n",
+// "event:" sets the dispatched type (defaulting to "message"), "id:"
+// updates the stream's LastEventID (unless its value contains a NUL,
+// which the spec says must be ignored), and "retry:" updates Retry when
+// its value consists only of ASCII digits. An event whose data buffer
+// ends up empty is dropped, as the spec requires, and NextDispatched
+// moves on to the next one. It returns a nil event and a nil error once
+// no complete event is buffered.
+func (p *ManualParser) NextDispatched() (*DispatchedEvent, error) {
+	for {
+		event, err := p.TryParse()
+		if err != nil {
+			return nil, err
+		}
+		if event == nil {
+			return nil, nil
+		}
+
+		var dataLines []string
+		eventType := ""
+		for _, f := range event.Fields() {
+			switch f.Name {
+			case "data":
+				dataLines = append(dataLines, f.Value)
+			case "event":
+				eventType = f.Value
+			case "id":
+				if !strings.ContainsRune(f.Value, 0) {
+					p.lastEventID = f.Value
+				}
+			case "retry":
+				if ms, ok := parseRetry(f.Value); ok {
+					p.retry = time.Duration(ms) * time.Millisecond
+				}
+			}
+		}
+
+		if len(dataLines) == 0 {
+			continue
+		}
+		if eventType == "" {
+			eventType = "message"
+		}
+		return &DispatchedEvent{
+			ID:    p.lastEventID,
+			Type:  eventType,
+			Data:  strings.Join(dataLines, "\n"),
+			Retry: p.retry,
+		}, nil
+	}
+}
+
+// LastEventID returns the most recent non-NUL-containing "id:" value seen
+// on the stream, so an HTTP client wrapper can send it back as
+// Last-Event-ID on reconnect.
+func (p *ManualParser) LastEventID() string { return p.lastEventID }
+
+// Retry returns the most recently received server-suggested reconnection
+// time, or zero if the stream has not sent one yet.
+func (p *ManualParser) Retry() time.Duration { return p.retry }
+
+// parseRetry reports the integer value of a "retry:" field, per the spec,
+// which requires the value to consist entirely of ASCII digits.
+func parseRetry(value string) (int, bool) {
+	if value == "" {
+		return 0, false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
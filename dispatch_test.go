@@ -0,0 +1,71 @@
+package sseparser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextDispatched_MultiLineData(t *testing.T) {
+	parser := NewManualParser()
+	require.NoError(t, parser.Append([]byte("data: line one\ndata: line two\n\n")))
+
+	event, err := parser.NextDispatched()
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "line one\nline two", event.Data)
+	assert.Equal(t, "message", event.Type, "type should default to message")
+}
+
+func TestNextDispatched_EmptyDataSuppressed(t *testing.T) {
+	parser := NewManualParser()
+	require.NoError(t, parser.Append([]byte("event: ping\n\ndata: real event\n\n")))
+
+	event, err := parser.NextDispatched()
+	require.NoError(t, err)
+	require.NotNil(t, event, "the event with no data lines should be skipped, not dispatched")
+	assert.Equal(t, "real event", event.Data)
+	assert.Equal(t, "message", event.Type, "the skipped event's type must not leak into the dispatched one")
+}
+
+func TestNextDispatched_NonNumericRetryIgnored(t *testing.T) {
+	parser := NewManualParser()
+	require.NoError(t, parser.Append([]byte("retry: soon\ndata: a\n\n")))
+
+	event, err := parser.NextDispatched()
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, time.Duration(0), event.Retry, "non-numeric retry values must be ignored")
+	assert.Equal(t, time.Duration(0), parser.Retry())
+}
+
+func TestNextDispatched_NumericRetryApplied(t *testing.T) {
+	parser := NewManualParser()
+	require.NoError(t, parser.Append([]byte("retry: 2500\ndata: a\n\n")))
+
+	event, err := parser.NextDispatched()
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, 2500*time.Millisecond, event.Retry)
+	assert.Equal(t, 2500*time.Millisecond, parser.Retry())
+}
+
+func TestNextDispatched_IDWithNULIgnored(t *testing.T) {
+	parser := NewManualParser()
+	require.NoError(t, parser.Append([]byte("id: 1\ndata: a\n\n")))
+
+	event, err := parser.NextDispatched()
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "1", event.ID)
+	assert.Equal(t, "1", parser.LastEventID())
+
+	require.NoError(t, parser.Append([]byte("id: 2\x003\ndata: b\n\n")))
+	event, err = parser.NextDispatched()
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "1", event.ID, "an id containing a NUL must be ignored, leaving the last valid id in place")
+	assert.Equal(t, "1", parser.LastEventID())
+}
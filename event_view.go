@@ -0,0 +1,95 @@
+package sseparser
+
+import (
+	"bytes"
+	"sync"
+)
+
+// FieldView is the zero-allocation counterpart of Field: Name and Value
+// alias directly into the parser's own buffer instead of owning copies
+// (see EventView for the one case where that buffer is a copy).
+type FieldView struct {
+	Name  []byte
+	Value []byte
+}
+
+// EventView is the zero-allocation counterpart of Event, populated by
+// TryParseInto. Its byte slices alias the parser's own segments, so it is
+// only valid until Release is called, which must happen before the next
+// Compact or TryParse/TryParseInto call on the same parser invalidates
+// the bytes it points into. An event whose bytes span more than one
+// segment is first copied into a single fresh slice, and the view aliases
+// that copy rather than the segments.
+type EventView struct {
+	Fields   []FieldView
+	Comments [][]byte
+}
+
+var eventViewPool = sync.Pool{
+	New: func() any { return new(EventView) },
+}
+
+// AcquireEventView returns an EventView from a shared pool, ready to be
+// passed to TryParseInto. Call Release once done with it.
+func AcquireEventView() *EventView {
+	return eventViewPool.Get().(*EventView)
+}
+
+// Release returns view to the shared pool and invalidates the byte
+// ranges it aliased. Callers must not read view, or any byte slice
+// obtained from it, after calling Release, and must call Release before
+// the next Compact or TryParse/TryParseInto call on the parser that
+// produced it, since either can drop or reuse the segments it points
+// into.
+//
+// Built with the sseparser_debug tag, Release first overwrites the
+// aliased bytes with a recognizable pattern, so a lingering read after
+// Release is caught as garbage in tests rather than silently returning
+// stale data.
+func (p *ManualParser) Release(view *EventView) {
+	poisonView(view)
+	view.Fields = view.Fields[:0]
+	view.Comments = view.Comments[:0]
+	eventViewPool.Put(view)
+}
+
+// TryParseInto behaves like TryParse but populates dst with FieldViews
+// that alias the parser's own segments instead of allocating owned
+// strings, for high-QPS proxying where most events are never inspected
+// field-by-field. An event spanning more than one segment is copied once
+// into a fresh slice that the FieldViews alias; an event within one
+// segment is not copied. It returns ok=false if no complete event is buffered
+// yet. Callers must Release dst before the next Compact or
+// TryParse/TryParseInto call.
+func (p *ManualParser) TryParseInto(dst *EventView) (ok bool, err error) {
+	raw, ok, err := p.nextEventBytes()
+	if err != nil || !ok {
+		return false, err
+	}
+	parseEventInto(raw, dst)
+	return true, nil
+}
+
+// parseEventInto is the alias-preserving counterpart of parseEvent: it
+// splits raw into FieldViews and comment byte slices without copying.
+func parseEventInto(raw []byte, dst *EventView) {
+	lines := bytes.Split(raw, []byte("\n"))
+	if n := len(lines); n > 0 && len(lines[n-1]) == 0 {
+		lines = lines[:n-1]
+	}
+	for _, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		if line[0] == ':' {
+			dst.Comments = append(dst.Comments, trimLeadingSpaceBytes(line[1:]))
+			continue
+		}
+		name, value := line, []byte(nil)
+		if i := bytes.IndexByte(line, ':'); i >= 0 {
+			name = line[:i]
+			value = trimLeadingSpaceBytes(line[i+1:])
+		}
+		dst.Fields = append(dst.Fields, FieldView{Name: name, Value: value})
+	}
+}
@@ -0,0 +1,41 @@
+package sseparser
+
+import "testing"
+
+// These benchmarks compare TryParse's owned-string path against
+// TryParseInto's buffer-aliasing path for the same workload, to quantify
+// the allocations avoided by proxying events without copying them.
+
+func BenchmarkTryParse_Copying(b *testing.B) {
+	raw := []byte("id: 1\ndata: benchmark payload line\n\n")
+	parser := NewManualParser()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := parser.Append(raw); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := parser.TryParse(); err != nil {
+			b.Fatal(err)
+		}
+		parser.Compact()
+	}
+}
+
+func BenchmarkTryParseInto_Aliasing(b *testing.B) {
+	raw := []byte("id: 1\ndata: benchmark payload line\n\n")
+	parser := NewManualParser()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := parser.Append(raw); err != nil {
+			b.Fatal(err)
+		}
+		view := AcquireEventView()
+		if _, err := parser.TryParseInto(view); err != nil {
+			b.Fatal(err)
+		}
+		parser.Release(view)
+		parser.Compact()
+	}
+}
@@ -0,0 +1,67 @@
+package sseparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryParseInto_AliasesParserBuffer(t *testing.T) {
+	parser := NewManualParser()
+	require.NoError(t, parser.Append([]byte("id: 1\ndata: aliased\n\n")))
+
+	view := AcquireEventView()
+	ok, err := parser.TryParseInto(view)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, view.Fields, 2)
+	assert.Equal(t, "id", string(view.Fields[0].Name))
+	assert.Equal(t, "1", string(view.Fields[0].Value))
+	assert.Equal(t, "data", string(view.Fields[1].Name))
+	assert.Equal(t, "aliased", string(view.Fields[1].Value))
+
+	// Mutating the view's bytes must be visible through ParsedBytes,
+	// proving the view aliases the parser's own buffer rather than a copy.
+	view.Fields[1].Value[0] = 'X'
+	assert.Contains(t, string(parser.ParsedBytes()), "Xliased")
+
+	parser.Release(view)
+}
+
+func TestTryParseInto_NoCompleteEvent(t *testing.T) {
+	parser := NewManualParser()
+	require.NoError(t, parser.Append([]byte("data: incomplete")))
+
+	view := AcquireEventView()
+	ok, err := parser.TryParseInto(view)
+	assert.False(t, ok)
+	assert.NoError(t, err)
+	parser.Release(view)
+}
+
+func TestTryParseInto_EventTooLarge(t *testing.T) {
+	parser := NewManualParser(WithMaxEventSize(4))
+	require.NoError(t, parser.Append([]byte("way too long")))
+
+	view := AcquireEventView()
+	ok, err := parser.TryParseInto(view)
+	assert.False(t, ok)
+	require.ErrorIs(t, err, ErrEventTooLarge)
+	parser.Release(view)
+}
+
+func TestRelease_ResetsViewForReuse(t *testing.T) {
+	parser := NewManualParser()
+	require.NoError(t, parser.Append([]byte("data: one\n\n")))
+
+	view := AcquireEventView()
+	ok, err := parser.TryParseInto(view)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, view.Fields, 1)
+
+	parser.Release(view)
+	assert.Empty(t, view.Fields, "Release should reset the view's field slice before returning it to the pool")
+	assert.Empty(t, view.Comments, "Release should reset the view's comment slice before returning it to the pool")
+}
@@ -0,0 +1,530 @@
+// Package sseparser implements an incremental parser for the
+// Server-Sent Events wire format (https://html.spec.whatwg.org/#server-sent-events).
+package sseparser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultCapacity is the size, in bytes, of the first segment allocated
+	// for a new ManualParser and the size a fully-compacted parser shrinks
+	// back down to.
+	defaultCapacity = 8192
+
+	// defaultResizeFactor is the growth factor applied to the segment size
+	// once an Append needs more room than the current segment size offers.
+	defaultResizeFactor = 1.5
+
+	// sliceHeaderOverhead approximates the bookkeeping cost of a Go slice
+	// header (pointer + len + cap words) charged per retained segment by
+	// MemSize.
+	sliceHeaderOverhead = 24
+)
+
+var (
+	// ErrEventTooLarge is returned, wrapped in an *EventTooLargeError, by
+	// TryParse when the unparsed region grows past the configured
+	// MaxEventSize before a "\n\n" delimiter is found.
+	ErrEventTooLarge = errors.New("sseparser: event exceeds configured max size")
+
+	// ErrBufferFull is returned by Append when writing data would grow the
+	// parser past its configured MaxBufferSize.
+	ErrBufferFull = errors.New("sseparser: append would exceed configured max buffer size")
+)
+
+// EventTooLargeError reports that TryParse gave up looking for a
+// delimiter after examining PrefixLen bytes of undelimited data. When it
+// is returned, the parser enters resync mode until the next "\n\n": it
+// silently discards bytes up to that boundary so the caller can keep
+// reading from the stream instead of tearing down the connection.
+type EventTooLargeError struct {
+	// PrefixLen is the number of unparsed bytes examined before the limit
+	// was hit.
+	PrefixLen int
+}
+
+func (e *EventTooLargeError) Error() string {
+	return fmt.Sprintf("sseparser: event exceeds max size after %d bytes", e.PrefixLen)
+}
+
+func (e *EventTooLargeError) Unwrap() error { return ErrEventTooLarge }
+
+// Option configures a ManualParser at construction time.
+type Option func(*ManualParser)
+
+// WithCapacity sets the size of the first segment allocated for the
+// parser. Values <= 0 are ignored and defaultCapacity is used instead.
+func WithCapacity(capacity int) Option {
+	return func(p *ManualParser) {
+		if capacity > 0 {
+			p.capacity = capacity
+		}
+	}
+}
+
+// WithResizeFactor sets the growth factor applied to the segment size once
+// a write no longer fits the current one. Factors <= 1 are ignored and
+// defaultResizeFactor is used instead.
+func WithResizeFactor(factor float64) Option {
+	return func(p *ManualParser) {
+		if factor > 1 {
+			p.resizeFactor = factor
+		}
+	}
+}
+
+// WithSegmentSize sets the size of segments allocated once the parser
+// outgrows its initial capacity. It defaults to the parser's capacity.
+// Values <= 0 are ignored.
+func WithSegmentSize(size int) Option {
+	return func(p *ManualParser) {
+		if size > 0 {
+			p.segmentSize = size
+		}
+	}
+}
+
+// WithMaxEventSize bounds how many unparsed bytes a single event may
+// accumulate before TryParse gives up and returns an *EventTooLargeError.
+// Values <= 0 disable the limit, which is the default.
+func WithMaxEventSize(size int) Option {
+	return func(p *ManualParser) {
+		if size > 0 {
+			p.maxEventSize = size
+		}
+	}
+}
+
+// WithMaxBufferSize bounds the total number of bytes the parser will
+// retain. Once reached, Append returns ErrBufferFull instead of growing
+// further. Values <= 0 disable the limit, which is the default.
+func WithMaxBufferSize(size int) Option {
+	return func(p *ManualParser) {
+		if size > 0 {
+			p.maxBufferSize = size
+		}
+	}
+}
+
+// Field is a single "name: value" line within an Event. Lines with no
+// colon are reported with an empty Value.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Event is the set of fields and comment lines found between two blank
+// lines in an SSE stream.
+type Event struct {
+	fields   []Field
+	comments []string
+}
+
+// Fields returns the event's "name: value" lines in the order they were
+// received.
+func (e *Event) Fields() []Field { return e.fields }
+
+// Comments returns the event's comment lines (those starting with ':'),
+// with the leading colon and at most one leading space stripped.
+func (e *Event) Comments() []string { return e.comments }
+
+// ManualParser incrementally parses SSE events out of bytes pushed to it
+// via Append. It never blocks on I/O: callers feed it bytes as they
+// arrive and poll TryParse for completed events.
+//
+// The unparsed and not-yet-compacted bytes are held as a list of
+// independently allocated segments ("views") rather than one contiguous
+// buffer. Append is O(1) (it fills the last view, or allocates a new one);
+// Compact reclaims consumed segments by dropping or re-slicing them in
+// place, so no memmove of the unparsed tail is ever required.
+type ManualParser struct {
+	views         [][]byte
+	size          int
+	parsedOffset  int
+	capacity      int
+	resizeFactor  float64
+	segmentSize   int
+	maxEventSize  int
+	maxBufferSize int
+	resyncing     bool
+	onEvent       func(*Event)
+	lastEventID   string
+	retry         time.Duration
+}
+
+// NewManualParser constructs a ManualParser ready to accept bytes via
+// Append.
+func NewManualParser(opts ...Option) *ManualParser {
+	p := &ManualParser{
+		capacity:     defaultCapacity,
+		resizeFactor: defaultResizeFactor,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.segmentSize <= 0 {
+		p.segmentSize = p.capacity
+	}
+	p.views = [][]byte{make([]byte, 0, p.capacity)}
+	return p
+}
+
+// Len reports the number of bytes currently retained by the parser,
+// including bytes already consumed by TryParse but not yet reclaimed by
+// Compact.
+func (p *ManualParser) Len() int { return p.size }
+
+// Cap reports the total capacity retained across all segments.
+func (p *ManualParser) Cap() int {
+	total := 0
+	for _, v := range p.views {
+		total += cap(v)
+	}
+	return total
+}
+
+// MemSize estimates the number of bytes retained in memory by the parser,
+// including unused segment capacity and per-segment slice-header
+// overhead, so callers can bound how much memory a long-lived parser is
+// allowed to hold on to.
+func (p *ManualParser) MemSize() int {
+	total := 0
+	for _, v := range p.views {
+		total += cap(v) + sliceHeaderOverhead
+	}
+	return total
+}
+
+// String returns the full retained contents, parsed and unparsed, as a
+// string. Intended for debugging and tests.
+func (p *ManualParser) String() string {
+	return string(p.flatten(0, p.size))
+}
+
+// ParsedBytes returns the bytes belonging to events already consumed by
+// TryParse but not yet released via Compact. It returns nil if nothing
+// has been parsed since the last Compact.
+func (p *ManualParser) ParsedBytes() []byte {
+	if p.parsedOffset == 0 {
+		return nil
+	}
+	return p.flatten(0, p.parsedOffset)
+}
+
+// UnparsedBytes returns the bytes TryParse has not yet consumed. When the
+// unparsed region spans more than one segment it is flattened into a
+// freshly allocated slice; callers on the hot path should prefer TryParse,
+// which copies only the bytes of a single event, and only when that event
+// spans more than one segment.
+func (p *ManualParser) UnparsedBytes() []byte {
+	return p.flatten(p.parsedOffset, p.size)
+}
+
+// Append copies data into the parser, filling any room left in the last
+// segment before allocating new ones sized to segmentSize (or larger, if a
+// single Append exceeds it). No existing bytes are ever copied or moved.
+//
+// If a MaxBufferSize was configured and appending data would exceed it,
+// Append leaves the parser unchanged and returns ErrBufferFull.
+func (p *ManualParser) Append(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if p.maxBufferSize > 0 && p.size+len(data) > p.maxBufferSize {
+		return ErrBufferFull
+	}
+	p.size += len(data)
+
+	if last := p.views[len(p.views)-1]; cap(last)-len(last) > 0 {
+		room := cap(last) - len(last)
+		take := room
+		if take > len(data) {
+			take = len(data)
+		}
+		p.views[len(p.views)-1] = append(last, data[:take]...)
+		data = data[take:]
+	}
+	for len(data) > 0 {
+		segCap := p.segmentSize
+		if len(data) > segCap {
+			segCap = len(data)
+			p.segmentSize = int(float64(segCap) * p.resizeFactor)
+		}
+		take := segCap
+		if take > len(data) {
+			take = len(data)
+		}
+		v := make([]byte, 0, segCap)
+		v = append(v, data[:take]...)
+		p.views = append(p.views, v)
+		data = data[take:]
+	}
+	return nil
+}
+
+// OnEvent registers the callback Write hands each complete event to as it
+// drains them off the parser. Registering a new callback replaces any
+// previous one.
+func (p *ManualParser) OnEvent(fn func(*Event)) {
+	p.onEvent = fn
+}
+
+// Write implements io.Writer: it appends data and then drains every event
+// TryParse can produce from it through the callback registered via
+// OnEvent, so a ManualParser can be wired directly to an io.Reader via
+// io.Copy without the caller writing its own Append/TryParse loop. Once
+// drained, it calls Compact so a parser fed this way doesn't retain
+// segments backing already-dispatched events for the life of the stream.
+//
+// Oversize events reported via *EventTooLargeError do not fail the Write;
+// the parser's resync handling already recovers on the next call, so
+// Write simply keeps draining.
+func (p *ManualParser) Write(data []byte) (int, error) {
+	if err := p.Append(data); err != nil {
+		return 0, err
+	}
+	for {
+		event, err := p.TryParse()
+		if err != nil {
+			var tooLarge *EventTooLargeError
+			if errors.As(err, &tooLarge) {
+				continue
+			}
+			return len(data), err
+		}
+		if event == nil {
+			p.Compact()
+			return len(data), nil
+		}
+		if p.onEvent != nil {
+			p.onEvent(event)
+		}
+	}
+}
+
+// Compact reclaims the segments backing bytes already consumed by
+// TryParse. Fully consumed segments are dropped outright; a segment that
+// is only partially consumed is re-sliced in place (views[0] =
+// views[0][n:]), which drops the consumed prefix without copying the
+// unparsed tail. If compacting leaves the parser empty, its segments are
+// replaced by a single fresh one sized to the configured capacity so a
+// long-lived connection doesn't keep an oversized segment pinned in
+// memory.
+func (p *ManualParser) Compact() {
+	remaining := p.parsedOffset
+	for remaining > 0 && len(p.views) > 0 {
+		v := p.views[0]
+		if len(v) <= remaining {
+			remaining -= len(v)
+			p.views = p.views[1:]
+			continue
+		}
+		p.views[0] = v[remaining:]
+		remaining = 0
+	}
+	p.size -= p.parsedOffset
+	p.parsedOffset = 0
+
+	if p.size == 0 {
+		p.views = [][]byte{make([]byte, 0, p.capacity)}
+	}
+}
+
+// TryParse looks for the next "\n\n" event delimiter in the unparsed
+// region and, if found, parses the bytes before it into an Event and
+// advances past the delimiter. It returns a nil Event and a nil error if
+// no complete event is available yet.
+//
+// If a MaxEventSize was configured and the unparsed region grows past it
+// without a delimiter, TryParse returns an *EventTooLargeError and enters
+// resync mode: it silently discards bytes (including everything currently
+// buffered) until the next "\n\n" boundary, so callers can recover by
+// simply continuing to Append and call TryParse rather than tearing down
+// the stream.
+func (p *ManualParser) TryParse() (*Event, error) {
+	raw, ok, err := p.nextEventBytes()
+	if err != nil || !ok {
+		return nil, err
+	}
+	return parseEvent(raw), nil
+}
+
+// nextEventBytes locates the next complete event, handling MaxEventSize
+// and resync exactly as TryParse does, and advances past it. It returns
+// ok=false if no complete event is buffered yet. The returned bytes alias
+// the parser's own segments (or, for an event spanning several segments,
+// a fresh copy made by flatten) and are only valid until the next
+// Compact/TryParse/TryParseInto call.
+func (p *ManualParser) nextEventBytes() (raw []byte, ok bool, err error) {
+	if p.resyncing {
+		if !p.resync() {
+			return nil, false, nil
+		}
+	}
+
+	idx := p.findDelimiter()
+	if idx < 0 {
+		if p.maxEventSize > 0 && p.size-p.parsedOffset > p.maxEventSize {
+			prefixLen := p.size - p.parsedOffset
+			p.resyncing = true
+			p.discardUnparsed()
+			return nil, false, &EventTooLargeError{PrefixLen: prefixLen}
+		}
+		return nil, false, nil
+	}
+	raw = p.flatten(p.parsedOffset, idx+1)
+	p.parsedOffset = idx + 2
+	return raw, true, nil
+}
+
+// resync looks for the next delimiter past parsedOffset. If one is found,
+// everything up to and including it is discarded (no event is produced)
+// and resync mode ends. Otherwise everything currently buffered is
+// discarded and the parser keeps waiting for more data.
+func (p *ManualParser) resync() bool {
+	idx := p.findDelimiter()
+	if idx < 0 {
+		p.discardUnparsed()
+		return false
+	}
+	p.parsedOffset = idx + 2
+	p.resyncing = false
+	return true
+}
+
+// discardUnparsed drops the unparsed bytes currently buffered, short of
+// the very last one. It's used whenever nextEventBytes/resync give up
+// looking for a delimiter in the buffered data: the final byte is kept
+// back rather than discarded because it may be the first half of a
+// "\n\n" delimiter that only completes once the next Append arrives, and
+// findDelimiter never looks at bytes before parsedOffset. Discarding it
+// outright would let that pairing slip past unnoticed and silently
+// swallow the event that follows it.
+func (p *ManualParser) discardUnparsed() {
+	if p.size > p.parsedOffset {
+		p.parsedOffset = p.size - 1
+		return
+	}
+	p.parsedOffset = p.size
+}
+
+// findDelimiter walks the views starting at parsedOffset looking for two
+// consecutive '\n' bytes, returning the absolute offset of the first one,
+// or -1 if the unparsed region holds no complete event yet. Segments
+// entirely before parsedOffset are skipped without inspecting their
+// bytes.
+func (p *ManualParser) findDelimiter() int {
+	global := 0
+	prev := byte(0)
+	havePrev := false
+	for _, v := range p.views {
+		vlen := len(v)
+		if global+vlen <= p.parsedOffset {
+			global += vlen
+			continue
+		}
+		start := 0
+		if global < p.parsedOffset {
+			start = p.parsedOffset - global
+		}
+		for i := start; i < vlen; i++ {
+			b := v[i]
+			if havePrev && prev == '\n' && b == '\n' {
+				return global + i - 1
+			}
+			prev = b
+			havePrev = true
+		}
+		global += vlen
+	}
+	return -1
+}
+
+// flatten returns the logical bytes in [start, end). If the range lies
+// entirely within one segment it is returned without copying; otherwise
+// it is copied into a freshly allocated slice.
+func (p *ManualParser) flatten(start, end int) []byte {
+	if start >= end {
+		return nil
+	}
+
+	pos := 0
+	for _, v := range p.views {
+		vlen := len(v)
+		if start >= pos && end <= pos+vlen {
+			return v[start-pos : end-pos]
+		}
+		if pos+vlen > start {
+			break
+		}
+		pos += vlen
+	}
+
+	out := make([]byte, 0, end-start)
+	pos = 0
+	for _, v := range p.views {
+		vlen := len(v)
+		segStart, segEnd := pos, pos+vlen
+		if segEnd > start && segStart < end {
+			lo, hi := 0, vlen
+			if start > segStart {
+				lo = start - segStart
+			}
+			if end < segEnd {
+				hi = end - segStart
+			}
+			out = append(out, v[lo:hi]...)
+		}
+		pos += vlen
+		if pos >= end {
+			break
+		}
+	}
+	return out
+}
+
+// parseEvent splits raw (the bytes of an event, up to and including the
+// newline terminating its last line) into fields and comments.
+func parseEvent(raw []byte) *Event {
+	event := &Event{}
+	lines := bytes.Split(raw, []byte("\n"))
+	if n := len(lines); n > 0 && len(lines[n-1]) == 0 {
+		lines = lines[:n-1]
+	}
+	for _, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		if line[0] == ':' {
+			event.comments = append(event.comments, trimLeadingSpace(line[1:]))
+			continue
+		}
+		name, value := line, []byte(nil)
+		if i := bytes.IndexByte(line, ':'); i >= 0 {
+			name = line[:i]
+			value = trimLeadingSpaceBytes(line[i+1:])
+		}
+		event.fields = append(event.fields, Field{Name: string(name), Value: string(value)})
+	}
+	return event
+}
+
+// trimLeadingSpace strips at most one leading space, per the SSE field
+// parsing algorithm.
+func trimLeadingSpace(b []byte) string {
+	return string(trimLeadingSpaceBytes(b))
+}
+
+// trimLeadingSpaceBytes is the byte-slice counterpart of trimLeadingSpace,
+// used where the caller needs to keep aliasing the parser's buffer
+// instead of copying into a string.
+func trimLeadingSpaceBytes(b []byte) []byte {
+	if len(b) > 0 && b[0] == ' ' {
+		return b[1:]
+	}
+	return b
+}
@@ -8,10 +8,10 @@ import (
 )
 
 const (
-	defaultTestCapacity = 8192
-	customTestCapacity  = 1024
-	defaultResizeFactor = 1.5
-	customResizeFactor  = 2.0
+	defaultTestCapacity     = 8192
+	customTestCapacity      = 1024
+	defaultTestResizeFactor = 1.5
+	customResizeFactor      = 2.0
 )
 
 func TestNewManualParser_Default(t *testing.T) {
@@ -20,7 +20,7 @@ func TestNewManualParser_Default(t *testing.T) {
 	assert.Equal(t, 0, parser.Len(), "Initial length should be 0")
 	assert.Equal(t, defaultTestCapacity, parser.Cap(), "Initial capacity should be the default")
 	assert.Equal(t, 0, parser.parsedOffset, "Initial parsed offset should be 0")
-	assert.Equal(t, defaultResizeFactor, parser.resizeFactor, "Default resize factor should be set")
+	assert.Equal(t, defaultTestResizeFactor, parser.resizeFactor, "Default resize factor should be set")
 }
 
 func TestNewManualParser_WithCapacity(t *testing.T) {
@@ -41,7 +41,7 @@ func TestNewManualParser_WithResizeFactor(t *testing.T) {
 
 	// Test with invalid resize factor
 	parser = NewManualParser(WithResizeFactor(0.5))
-	assert.Equal(t, defaultResizeFactor, parser.resizeFactor, "Resize factor <= 1 should be ignored, fallback to default")
+	assert.Equal(t, defaultTestResizeFactor, parser.resizeFactor, "Resize factor <= 1 should be ignored, fallback to default")
 }
 
 func TestNewManualParser_MultipleOptions(t *testing.T) {
@@ -138,7 +138,7 @@ func TestCompact_Basic(t *testing.T) {
 
 	assert.Equal(t, 0, parser.parsedOffset, "Offset should be reset after compact")
 	assert.Equal(t, len(remainingData), parser.Len(), "Length should be size of remaining data")
-	assert.Equal(t, string(remainingData), string(parser.buf), "Buffer should contain only the unparsed data")
+	assert.Equal(t, string(remainingData), string(parser.UnparsedBytes()), "Buffer should contain only the unparsed data")
 }
 
 func TestCompact_BufferShrinking(t *testing.T) {
@@ -249,7 +249,7 @@ func TestAuxiliaryMethods(t *testing.T) {
 	assert.Equal(t, len(event1Data)+len(unparsedData), parser.Len())
 	assert.Equal(t, string(event1Data)+string(unparsedData), parser.String())
 	assert.Nil(t, parser.ParsedBytes())
-	assert.Equal(t, string(parser.buf), string(parser.UnparsedBytes()))
+	assert.Equal(t, parser.String(), string(parser.UnparsedBytes()))
 
 	// Parse one event
 	_, err := parser.TryParse()
@@ -267,3 +267,123 @@ func TestAuxiliaryMethods(t *testing.T) {
 	assert.Nil(t, parser.ParsedBytes(), "ParsedBytes should be nil after compact")
 	assert.Equal(t, string(unparsedData), string(parser.UnparsedBytes()))
 }
+
+// --- 5. 分段缓冲区测试 ---
+
+func TestNewManualParser_WithSegmentSize(t *testing.T) {
+	parser := NewManualParser(WithCapacity(16), WithSegmentSize(32))
+	assert.Equal(t, 16, parser.capacity)
+	assert.Equal(t, 32, parser.segmentSize)
+
+	// Invalid segment size is ignored, falling back to the capacity.
+	parser = NewManualParser(WithCapacity(16), WithSegmentSize(0))
+	assert.Equal(t, 16, parser.segmentSize)
+}
+
+func TestAppend_SpansMultipleSegments(t *testing.T) {
+	parser := NewManualParser(WithCapacity(8), WithSegmentSize(8))
+
+	// The first Append fills the initial segment exactly; each later one
+	// finds no room left in it, forcing a new view to be allocated rather
+	// than a memmove of the existing ones.
+	parser.Append([]byte("data: fi"))
+	parser.Append([]byte("rst mess"))
+	parser.Append([]byte("age\n\n"))
+	assert.True(t, len(parser.views) > 1, "data spanning multiple Appends should live in multiple segments")
+
+	event, err := parser.TryParse()
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "data", event.Fields()[0].Name)
+	assert.Equal(t, "first message", event.Fields()[0].Value)
+}
+
+func TestMemSize(t *testing.T) {
+	parser := NewManualParser(WithCapacity(64))
+	assert.Equal(t, 64+sliceHeaderOverhead, parser.MemSize())
+
+	parser.Append(make([]byte, 100))
+	assert.True(t, parser.MemSize() >= parser.Cap(), "MemSize should account for at least the retained capacity")
+}
+
+// --- 6. 大小限制与背压测试 ---
+
+func TestTryParse_EventTooLarge_SingleAppend(t *testing.T) {
+	parser := NewManualParser(WithMaxEventSize(10))
+	require.NoError(t, parser.Append([]byte("this line has no delimiter yet")))
+
+	event, err := parser.TryParse()
+	assert.Nil(t, event)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrEventTooLarge)
+
+	var tooLarge *EventTooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, len("this line has no delimiter yet"), tooLarge.PrefixLen)
+}
+
+func TestTryParse_EventTooLarge_SpreadAcrossAppends(t *testing.T) {
+	parser := NewManualParser(WithMaxEventSize(10))
+	for _, chunk := range []string{"012345", "6789", "ab"} {
+		require.NoError(t, parser.Append([]byte(chunk)))
+	}
+
+	event, err := parser.TryParse()
+	assert.Nil(t, event)
+	require.ErrorIs(t, err, ErrEventTooLarge)
+}
+
+func TestTryParse_RecoversAfterResync(t *testing.T) {
+	parser := NewManualParser(WithMaxEventSize(10))
+	require.NoError(t, parser.Append([]byte("this is way too long to fit")))
+
+	_, err := parser.TryParse()
+	require.ErrorIs(t, err, ErrEventTooLarge)
+
+	// No boundary yet: the parser stays in resync, silently dropping
+	// whatever it has buffered while it waits for more data.
+	event, err := parser.TryParse()
+	assert.Nil(t, event)
+	assert.NoError(t, err)
+
+	// Once a delimiter arrives, the parser discards up to it and resumes
+	// normal parsing for whatever follows.
+	require.NoError(t, parser.Append([]byte("\n\ndata: recovered\n\n")))
+	event, err = parser.TryParse()
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "data", event.Fields()[0].Name)
+	assert.Equal(t, "recovered", event.Fields()[0].Value)
+}
+
+func TestTryParse_RecoversAfterResync_SplitDelimiter(t *testing.T) {
+	parser := NewManualParser(WithMaxEventSize(5))
+
+	require.NoError(t, parser.Append([]byte("abcdef\n")))
+	_, err := parser.TryParse()
+	require.ErrorIs(t, err, ErrEventTooLarge)
+
+	// The lone '\n' left dangling at the end of the discarded prefix must
+	// be kept as the possible first half of the delimiter that closes
+	// resync, not thrown away with the rest of the oversize data.
+	require.NoError(t, parser.Append([]byte("\n")))
+	event, err := parser.TryParse()
+	assert.Nil(t, event)
+	assert.NoError(t, err)
+
+	require.NoError(t, parser.Append([]byte("data: recovered\n\n")))
+	event, err = parser.TryParse()
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "data", event.Fields()[0].Name)
+	assert.Equal(t, "recovered", event.Fields()[0].Value)
+}
+
+func TestAppend_BufferFull(t *testing.T) {
+	parser := NewManualParser(WithMaxBufferSize(10))
+	require.NoError(t, parser.Append([]byte("12345")))
+
+	err := parser.Append([]byte("678901"))
+	require.ErrorIs(t, err, ErrBufferFull)
+	assert.Equal(t, 5, parser.Len(), "a rejected Append must not partially grow the buffer")
+}
@@ -0,0 +1,24 @@
+//go:build sseparser_debug
+
+package sseparser
+
+// poisonPattern is written over an EventView's aliased bytes on Release,
+// so a lingering read of released data shows up as obvious garbage
+// instead of silently returning stale-but-plausible bytes.
+const poisonPattern = 0xDE
+
+func poisonView(view *EventView) {
+	for _, f := range view.Fields {
+		poisonBytes(f.Name)
+		poisonBytes(f.Value)
+	}
+	for _, c := range view.Comments {
+		poisonBytes(c)
+	}
+}
+
+func poisonBytes(b []byte) {
+	for i := range b {
+		b[i] = poisonPattern
+	}
+}
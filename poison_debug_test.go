@@ -0,0 +1,28 @@
+//go:build sseparser_debug
+
+package sseparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelease_PoisonsViewInDebugBuilds(t *testing.T) {
+	parser := NewManualParser()
+	require.NoError(t, parser.Append([]byte("data: sensitive\n\n")))
+
+	view := AcquireEventView()
+	ok, err := parser.TryParseInto(view)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	value := view.Fields[0].Value
+	parser.Release(view)
+
+	require.NotEmpty(t, value)
+	for _, b := range value {
+		assert.Equal(t, byte(poisonPattern), b, "released bytes should be poisoned in debug builds")
+	}
+}
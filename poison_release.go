@@ -0,0 +1,7 @@
+//go:build !sseparser_debug
+
+package sseparser
+
+// poisonView is a no-op in non-debug builds; see poison_debug.go, built
+// with the sseparser_debug tag, for the guard against use-after-Release.
+func poisonView(view *EventView) {}
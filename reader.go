@@ -0,0 +1,120 @@
+package sseparser
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// readChunkSize is the size of the scratch buffer ReaderParser uses to
+// pull bytes off its io.Reader.
+const readChunkSize = 4096
+
+// ReaderParser drives a ManualParser off an io.Reader, so callers don't
+// have to write their own Read/Append/TryParse loop.
+type ReaderParser struct {
+	r      io.Reader
+	parser *ManualParser
+	buf    []byte
+}
+
+// NewReaderParser constructs a ReaderParser that reads from r and parses
+// its contents as SSE events. opts are forwarded to the underlying
+// ManualParser.
+func NewReaderParser(r io.Reader, opts ...Option) *ReaderParser {
+	return &ReaderParser{
+		r:      r,
+		parser: NewManualParser(opts...),
+		buf:    make([]byte, readChunkSize),
+	}
+}
+
+// Next returns the next complete event, reading from the underlying
+// io.Reader as needed. It returns io.EOF once the reader is exhausted and
+// no partial event remains buffered; if the reader is exhausted mid-event
+// it returns io.ErrUnexpectedEOF instead so callers can distinguish a
+// clean close from a truncated stream.
+//
+// Once the parser has no more complete events buffered, Next compacts it
+// before reading more, so a long-lived stream doesn't keep the segments
+// backing already-dispatched events pinned in memory.
+//
+// Oversize events reported via *EventTooLargeError do not fail Next; like
+// Write, it swallows them and keeps reading so the parser's resync
+// recovery can dispatch whatever follows the oversized event instead of
+// tearing down the stream.
+func (rp *ReaderParser) Next(ctx context.Context) (*Event, error) {
+	for {
+		event, err := rp.parser.TryParse()
+		if err != nil {
+			var tooLarge *EventTooLargeError
+			if errors.As(err, &tooLarge) {
+				continue
+			}
+			return nil, err
+		}
+		if event != nil {
+			return event, nil
+		}
+		rp.parser.Compact()
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		n, rerr := rp.r.Read(rp.buf)
+		if n > 0 {
+			if appendErr := rp.parser.Append(rp.buf[:n]); appendErr != nil {
+				return nil, appendErr
+			}
+		}
+		if rerr != nil {
+			if errors.Is(rerr, io.EOF) {
+				if n > 0 {
+					// Parse what this final Read delivered first; the next
+					// Read reports io.EOF again with no data.
+					continue
+				}
+				if len(rp.parser.UnparsedBytes()) > 0 {
+					return nil, io.ErrUnexpectedEOF
+				}
+				return nil, io.EOF
+			}
+			return nil, rerr
+		}
+	}
+}
+
+// NewStreamParser reads from r in the background and pushes decoded
+// events onto the returned channel until r is exhausted or ctx is
+// cancelled, at which point both channels are closed. At most one error
+// is ever sent on the error channel; io.EOF is treated as a clean end of
+// stream and is not reported as an error.
+func NewStreamParser(ctx context.Context, r io.Reader, opts ...Option) (<-chan *Event, <-chan error) {
+	events := make(chan *Event)
+	errs := make(chan error, 1)
+	rp := NewReaderParser(r, opts...)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		for {
+			event, err := rp.Next(ctx)
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					errs <- err
+				}
+				return
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return events, errs
+}
@@ -0,0 +1,195 @@
+package sseparser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderParser_Next(t *testing.T) {
+	r := strings.NewReader("data: first\n\ndata: second\n\n")
+	rp := NewReaderParser(r)
+
+	event1, err := rp.Next(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, event1)
+	assert.Equal(t, "first", event1.Fields()[0].Value)
+
+	event2, err := rp.Next(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, event2)
+	assert.Equal(t, "second", event2.Fields()[0].Value)
+
+	event3, err := rp.Next(context.Background())
+	assert.Nil(t, event3)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReaderParser_Next_UnexpectedEOF(t *testing.T) {
+	r := strings.NewReader("data: incomplete")
+	rp := NewReaderParser(r)
+
+	event, err := rp.Next(context.Background())
+	assert.Nil(t, event)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+// TestReaderParser_Next_RecoversFromOversizeEvent guards the resync
+// recovery chunk0-2 documents ("callers can recover instead of tearing
+// down the stream"): an oversized event must not surface as a fatal error
+// from Next, and whatever follows it once resync finds a delimiter must
+// still be dispatched. The garbage is fed in small chunks so MaxEventSize
+// is actually tripped before the delimiter arrives, rather than the whole
+// thing landing in one Read.
+func TestReaderParser_Next_RecoversFromOversizeEvent(t *testing.T) {
+	data := []byte(strings.Repeat("x", 30) + "\n\ndata: recovered\n\n")
+	r := &chunkedReader{data: data, chunkLen: 5}
+	rp := NewReaderParser(r, WithMaxEventSize(20))
+
+	event, err := rp.Next(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "data", event.Fields()[0].Name)
+	assert.Equal(t, "recovered", event.Fields()[0].Value)
+}
+
+func TestNewStreamParser_RecoversFromOversizeEvent(t *testing.T) {
+	data := []byte(strings.Repeat("x", 30) + "\n\ndata: recovered\n\n")
+	r := &chunkedReader{data: data, chunkLen: 5}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events, errs := NewStreamParser(ctx, r, WithMaxEventSize(20))
+
+	var got []string
+	for event := range events {
+		got = append(got, event.Fields()[0].Value)
+	}
+	assert.Equal(t, []string{"recovered"}, got, "the oversize event must be skipped, not treated as fatal")
+
+	_, ok := <-errs
+	assert.False(t, ok, "error channel should be closed with no error once the stream recovers and hits EOF")
+}
+
+// blockingReader never returns data or an error; it exists to prove Next
+// checks ctx before, not instead of, reading.
+type blockingReader struct{}
+
+func (blockingReader) Read([]byte) (int, error) { return 0, nil }
+
+func TestReaderParser_Next_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rp := NewReaderParser(blockingReader{})
+	event, err := rp.Next(ctx)
+	assert.Nil(t, event)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewStreamParser(t *testing.T) {
+	r := strings.NewReader("data: first\n\ndata: second\n\n")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events, errs := NewStreamParser(ctx, r)
+
+	var got []string
+	for event := range events {
+		got = append(got, event.Fields()[0].Value)
+	}
+	assert.Equal(t, []string{"first", "second"}, got)
+
+	_, ok := <-errs
+	assert.False(t, ok, "error channel should be closed with no error after a clean EOF")
+}
+
+func TestManualParser_Write(t *testing.T) {
+	parser := NewManualParser()
+	var got []string
+	parser.OnEvent(func(e *Event) {
+		got = append(got, e.Fields()[0].Value)
+	})
+
+	var w io.Writer = parser
+	data := []byte("data: first\n\ndata: second\n\ndata: incomp")
+	n, err := w.Write(data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, []string{"first", "second"}, got)
+}
+
+// TestManualParser_Write_CompactsAfterDraining guards against Write
+// retaining the segments backing events it has already dispatched: a
+// parser fed many small events through Write, one at a time, should settle
+// back down to holding only whatever is left unparsed, and a bounded
+// buffer should never fill up as long as each Write is drained before the
+// next one arrives.
+func TestManualParser_Write_CompactsAfterDraining(t *testing.T) {
+	parser := NewManualParser(WithMaxBufferSize(64))
+	var got []string
+	parser.OnEvent(func(e *Event) {
+		got = append(got, e.Fields()[0].Value)
+	})
+
+	for i := 0; i < 200; i++ {
+		_, err := parser.Write([]byte(fmt.Sprintf("data: event-%d\n\n", i)))
+		require.NoError(t, err, "Write must reclaim drained events instead of exhausting MaxBufferSize")
+	}
+
+	require.Len(t, got, 200)
+	assert.Equal(t, 0, parser.Len(), "parser should hold nothing once every event has been drained")
+}
+
+// chunkedReader serves data in fixed-size chunks regardless of the
+// caller's read buffer, so tests can drive a bounded-buffer parser
+// through reads that are each individually smaller than MaxBufferSize
+// even though the stream as a whole is not.
+type chunkedReader struct {
+	data     []byte
+	chunkLen int
+}
+
+func (r *chunkedReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := r.chunkLen
+	if n > len(p) {
+		n = len(p)
+	}
+	if n > len(r.data) {
+		n = len(r.data)
+	}
+	copy(p, r.data[:n])
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestNewStreamParser_BoundedBufferReclaimsAfterDraining(t *testing.T) {
+	var data []byte
+	for i := 0; i < 200; i++ {
+		data = append(data, []byte(fmt.Sprintf("data: event-%d\n\n", i))...)
+	}
+	r := &chunkedReader{data: data, chunkLen: 16}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events, errs := NewStreamParser(ctx, r, WithMaxBufferSize(64))
+
+	var got int
+	for range events {
+		got++
+	}
+	assert.Equal(t, 200, got, "every event should be dispatched without ErrBufferFull interrupting the stream")
+
+	_, ok := <-errs
+	assert.False(t, ok, "error channel should be closed with no error after a clean EOF")
+}